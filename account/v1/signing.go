@@ -0,0 +1,36 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/SeaVerseAI/seaverse-go/signature/middleware"
+	signature "github.com/SeaVerseAI/seaverse-go/signature/v1"
+)
+
+// WithSignedRequests returns a ClientOption that signs every outbound
+// request made by the client: it wraps the client's transport with
+// middleware.Transport, which injects a fresh timestamp and nonce and
+// signs the result with signer.
+//
+// Example:
+//
+//	client, err := v1.NewDefaultClient(v1.WithSignedRequests(signature.NewSigner(secret)))
+func WithSignedRequests(signer *signature.Signer, opts ...middleware.Option) ClientOption {
+	return func(c *Client) {
+		t := middleware.NewTransport(signer, opts...)
+		t.Base = c.httpClient.Transport
+		c.httpClient.Transport = t
+	}
+}
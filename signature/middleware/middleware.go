@@ -0,0 +1,228 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package middleware wires signature.Signer into Gin and net/http servers
+// so that request signing and verification don't need to be hand-rolled
+// per handler.
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	signature "github.com/SeaVerseAI/seaverse-go/signature/v1"
+)
+
+// DefaultHeader is the header name Verifier and Transport use when none is
+// configured via WithHeader.
+const DefaultHeader = "X-Seaverse-Signature"
+
+// verifier is the subset of *signature.Signer and *signature.RingSigner
+// that the middleware needs, so it isn't tied to one signer implementation.
+type verifier interface {
+	Verify(params map[string]any, signature string) (bool, error)
+}
+
+type config struct {
+	header       string
+	hashBody     bool
+	bodyHashKey  string
+	maxBodyBytes int64
+}
+
+// Option configures Verifier, HTTPMiddleware, and Transport.
+type Option func(*config)
+
+// WithHeader overrides the header name used to carry the signature.
+// Defaults to DefaultHeader.
+func WithHeader(name string) Option {
+	return func(c *config) { c.header = name }
+}
+
+// WithBodyHashing makes the middleware sign a SHA-256 hash of the raw
+// request body (under the "body_sha256" param) instead of flattening the
+// JSON body into individual params. Use this for streaming or large
+// bodies, where buffering every field into the params map is wasteful.
+func WithBodyHashing() Option {
+	return func(c *config) { c.hashBody = true }
+}
+
+// WithMaxBodyBytes caps how much of the request body is read when
+// extracting params or computing a body hash. Zero (the default) means
+// unlimited.
+func WithMaxBodyBytes(n int64) Option {
+	return func(c *config) { c.maxBodyBytes = n }
+}
+
+func newConfig(opts ...Option) config {
+	c := config{header: DefaultHeader, bodyHashKey: "body_sha256"}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Verifier returns a gin.HandlerFunc that extracts signed params from the
+// request's query, form, and JSON body, verifies them against the
+// signature carried in the configured header, and aborts with 401 if the
+// signature is missing or invalid, or 403 if verification errors.
+func Verifier(signer verifier, opts ...Option) gin.HandlerFunc {
+	cfg := newConfig(opts...)
+	return func(c *gin.Context) {
+		sig := c.GetHeader(cfg.header)
+		if sig == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing signature header"})
+			return
+		}
+
+		params, err := extractParams(c.Request, cfg)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		valid, err := signer.Verify(params, sig)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if !valid {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid signature"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// HTTPMiddleware is the net/http equivalent of Verifier, for services that
+// don't use Gin.
+func HTTPMiddleware(signer verifier, opts ...Option) func(http.Handler) http.Handler {
+	cfg := newConfig(opts...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sig := r.Header.Get(cfg.header)
+			if sig == "" {
+				http.Error(w, "missing signature header", http.StatusUnauthorized)
+				return
+			}
+
+			params, err := extractParams(r, cfg)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			valid, err := signer.Verify(params, sig)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			if !valid {
+				http.Error(w, "invalid signature", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// extractParams builds the params map that gets verified: query string
+// values, form values, and (unless body hashing is enabled) the top-level
+// fields of a JSON body. The request body is buffered and restored so
+// downstream handlers can still read it; when WithMaxBodyBytes is set, only
+// the bytes read up to that cap are restored and the rest is dropped.
+func extractParams(r *http.Request, cfg config) (map[string]any, error) {
+	params := map[string]any{}
+	for k, values := range r.URL.Query() {
+		if len(values) > 0 {
+			params[k] = values[0]
+		}
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		return params, nil
+	}
+
+	var reader io.Reader = r.Body
+	if cfg.maxBodyBytes > 0 {
+		reader = io.LimitReader(r.Body, cfg.maxBodyBytes)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(body) == 0 {
+		return params, nil
+	}
+
+	if cfg.hashBody {
+		hash := sha256.Sum256(body)
+		params[cfg.bodyHashKey] = hex.EncodeToString(hash[:])
+		return params, nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	switch {
+	case contentType == "" || contentType == "application/json" || hasJSONPrefix(contentType):
+		var fields map[string]any
+		if err := json.Unmarshal(body, &fields); err == nil {
+			for k, v := range fields {
+				params[k] = v
+			}
+		}
+	case hasFormPrefix(contentType):
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("signature: parse form body: %w", err)
+		}
+		for k, values := range form {
+			if len(values) > 0 {
+				params[k] = values[0]
+			}
+		}
+	}
+
+	return params, nil
+}
+
+func hasJSONPrefix(contentType string) bool {
+	return len(contentType) >= len("application/json") && contentType[:len("application/json")] == "application/json"
+}
+
+const formContentType = "application/x-www-form-urlencoded"
+
+func hasFormPrefix(contentType string) bool {
+	return len(contentType) >= len(formContentType) && contentType[:len(formContentType)] == formContentType
+}
+
+// signer is the subset of *signature.Signer that Transport needs to sign
+// outbound requests.
+type signer interface {
+	Sign(params map[string]any) (string, error)
+}
+
+var _ signer = (*signature.Signer)(nil)
+var _ verifier = (*signature.Signer)(nil)
@@ -0,0 +1,232 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	signature "github.com/SeaVerseAI/seaverse-go/signature/v1"
+)
+
+func TestHTTPMiddleware_ValidSignature(t *testing.T) {
+	signer := signature.NewSigner("test-secret")
+
+	handler := HTTPMiddleware(signer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts?action=list", nil)
+	sig, err := signer.Sign(map[string]any{"action": "list"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	req.Header.Set(DefaultHeader, sig)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestHTTPMiddleware_MissingSignature(t *testing.T) {
+	signer := signature.NewSigner("test-secret")
+	handler := HTTPMiddleware(signer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts?action=list", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestHTTPMiddleware_InvalidSignature(t *testing.T) {
+	signer := signature.NewSigner("test-secret")
+	handler := HTTPMiddleware(signer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts?action=list", nil)
+	req.Header.Set(DefaultHeader, "not-a-real-signature")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestHTTPMiddleware_JSONBody(t *testing.T) {
+	signer := signature.NewSigner("test-secret")
+	handler := HTTPMiddleware(signer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if len(body) == 0 {
+			t.Errorf("expected body to be readable downstream")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(`{"action":"create"}`))
+	req.Header.Set("Content-Type", "application/json")
+	sig, err := signer.Sign(map[string]any{"action": "create"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	req.Header.Set(DefaultHeader, sig)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestHTTPMiddleware_FormBody(t *testing.T) {
+	signer := signature.NewSigner("test-secret")
+	handler := HTTPMiddleware(signer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader("action=create"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	sig, err := signer.Sign(map[string]any{"action": "create"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	req.Header.Set(DefaultHeader, sig)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+}
+
+// TestHTTPMiddleware_FormBody_Tampered verifies a form field is actually
+// bound to the signature, not silently excluded from verification.
+func TestHTTPMiddleware_FormBody_Tampered(t *testing.T) {
+	signer := signature.NewSigner("test-secret")
+	handler := HTTPMiddleware(signer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader("action=delete"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	sig, err := signer.Sign(map[string]any{"action": "create"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	req.Header.Set(DefaultHeader, sig)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for tampered form field, got %d", rec.Code)
+	}
+}
+
+func TestTransport_SignsOutboundRequests(t *testing.T) {
+	signer := signature.NewSigner("test-secret")
+
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(signer)}
+	resp, err := client.Get(server.URL + "/accounts")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if received.Header.Get(DefaultHeader) == "" {
+		t.Errorf("expected signature header to be set")
+	}
+	if received.URL.Query().Get("timestamp") == "" {
+		t.Errorf("expected timestamp query param to be set")
+	}
+	if received.URL.Query().Get("nonce") == "" {
+		t.Errorf("expected nonce query param to be set")
+	}
+}
+
+// TestTransport_SignsBody verifies Transport and HTTPMiddleware agree on
+// what gets signed for a request with a JSON body: a POST signed by
+// Transport must verify against HTTPMiddleware on the other end, not just
+// against the bodyless GET case above.
+func TestTransport_SignsBody(t *testing.T) {
+	signer := signature.NewSigner("test-secret")
+
+	var gotStatus int
+	verified := HTTPMiddleware(signer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verified.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(signer)}
+	resp, err := client.Post(server.URL+"/accounts", "application/json", strings.NewReader(`{"action":"create"}`))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	defer resp.Body.Close()
+	gotStatus = resp.StatusCode
+
+	if gotStatus != http.StatusOK {
+		t.Errorf("expected 200, got %d", gotStatus)
+	}
+}
+
+func TestTransport_SignsBody_BodyHashing(t *testing.T) {
+	signer := signature.NewSigner("test-secret")
+
+	verified := HTTPMiddleware(signer, WithBodyHashing())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"action":"create"}` {
+			t.Errorf("expected body to be readable downstream, got %q", body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server := httptest.NewServer(verified)
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(signer, WithBodyHashing())}
+	resp, err := client.Post(server.URL+"/accounts", "application/json", strings.NewReader(`{"action":"create"}`))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+}
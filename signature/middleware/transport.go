@@ -0,0 +1,95 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Transport is an http.RoundTripper that automatically signs outbound
+// requests: it injects a fresh "timestamp" and "nonce" query param into
+// every request, signs the resulting params with Signer, and sets the
+// signature on the configured header. Pair it with a Signer that enforces
+// a matching Policy server-side (see signature.Signer.VerifyWithPolicy)
+// to get replay protection for free.
+type Transport struct {
+	Signer signer
+	Base   http.RoundTripper
+	Header string
+	cfg    config
+}
+
+// NewTransport creates a Transport that signs every request it proxies
+// using signer.
+func NewTransport(s signer, opts ...Option) *Transport {
+	cfg := newConfig(opts...)
+	return &Transport{Signer: s, Header: cfg.header, cfg: cfg}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	clone := req.Clone(req.Context())
+
+	nonce, err := randomNonce()
+	if err != nil {
+		return nil, fmt.Errorf("signature: generate nonce: %w", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	query := clone.URL.Query()
+	query.Set("timestamp", timestamp)
+	query.Set("nonce", nonce)
+	clone.URL.RawQuery = query.Encode()
+
+	// Extract the same params a Verifier/HTTPMiddleware on the receiving
+	// end will extract (query + form + JSON body, or a body hash when
+	// WithBodyHashing is set), so the signature this Transport produces
+	// actually covers the request body.
+	params, err := extractParams(clone, t.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("signature: extract params: %w", err)
+	}
+
+	header := t.Header
+	if header == "" {
+		header = DefaultHeader
+	}
+
+	sig, err := t.Signer.Sign(params)
+	if err != nil {
+		return nil, fmt.Errorf("signature: sign request: %w", err)
+	}
+	clone.Header.Set(header, sig)
+
+	return base.RoundTrip(clone)
+}
+
+func randomNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,308 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+)
+
+// KeyType identifies the signing algorithm family of an asymmetric key.
+type KeyType int
+
+const (
+	// KeyTypeEd25519 identifies an Ed25519 key pair.
+	KeyTypeEd25519 KeyType = iota + 1
+	// KeyTypeRSA identifies an RSA key pair signed with RSA-PSS.
+	KeyTypeRSA
+	// KeyTypeECDSA identifies an ECDSA key pair over a NIST curve.
+	KeyTypeECDSA
+)
+
+// String returns a human-readable name for the key type.
+func (t KeyType) String() string {
+	switch t {
+	case KeyTypeEd25519:
+		return "Ed25519"
+	case KeyTypeRSA:
+		return "RSA"
+	case KeyTypeECDSA:
+		return "ECDSA"
+	default:
+		return "unknown"
+	}
+}
+
+// KeySpec describes an asymmetric key's algorithm family and size.
+//
+// Size is the RSA modulus size or ECDSA curve size in bits; it is zero for
+// Ed25519, which has a single fixed size.
+type KeySpec struct {
+	Type KeyType
+	Size int
+}
+
+// KeySpecFromCertificate derives a KeySpec from the public key embedded in
+// an X.509 certificate, mirroring the certificate-driven key inspection
+// used by notation-core-go.
+func KeySpecFromCertificate(cert *x509.Certificate) (KeySpec, error) {
+	return keySpecFromPublicKey(cert.PublicKey)
+}
+
+func keySpecFromPublicKey(pub crypto.PublicKey) (KeySpec, error) {
+	switch key := pub.(type) {
+	case ed25519.PublicKey:
+		return KeySpec{Type: KeyTypeEd25519}, nil
+	case *rsa.PublicKey:
+		switch key.N.BitLen() {
+		case 2048, 3072, 4096:
+			return KeySpec{Type: KeyTypeRSA, Size: key.N.BitLen()}, nil
+		default:
+			return KeySpec{}, fmt.Errorf("signature: unsupported RSA key size %d bits", key.N.BitLen())
+		}
+	case *ecdsa.PublicKey:
+		switch key.Curve.Params().BitSize {
+		case 256, 384:
+			return KeySpec{Type: KeyTypeECDSA, Size: key.Curve.Params().BitSize}, nil
+		default:
+			return KeySpec{}, fmt.Errorf("signature: unsupported ECDSA curve size %d bits", key.Curve.Params().BitSize)
+		}
+	default:
+		return KeySpec{}, fmt.Errorf("signature: unsupported public key type %T", pub)
+	}
+}
+
+// AsymSigner signs parameters with an asymmetric private key (Ed25519,
+// RSA-PSS, or ECDSA), for use when the verifying party has no shared
+// secret with the signer, e.g. outbound webhook delivery.
+type AsymSigner struct {
+	key  crypto.Signer
+	spec KeySpec
+}
+
+// NewAsymSigner creates an AsymSigner from a private key implementing
+// crypto.Signer. The supported concrete types are ed25519.PrivateKey,
+// *rsa.PrivateKey (2048/3072/4096 bits), and *ecdsa.PrivateKey (P-256 or
+// P-384).
+func NewAsymSigner(privKey crypto.Signer) (*AsymSigner, error) {
+	if privKey == nil {
+		return nil, fmt.Errorf("signature: private key cannot be nil")
+	}
+	spec, err := keySpecFromPublicKey(privKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &AsymSigner{key: privKey, spec: spec}, nil
+}
+
+// KeySpec returns the KeySpec of the signer's private key.
+func (s *AsymSigner) KeySpec() KeySpec {
+	return s.spec
+}
+
+// Sign generates an asymmetric signature from the provided parameters.
+//
+// Parameters are canonicalized using the same rules as Signer.Sign with its
+// default RawCanonicalizer (no other Canonicalizer can be selected), then
+// signed directly (Ed25519) or over a SHA-256 digest (RSA-PSS, ECDSA).
+// The output is a hexadecimal lowercase string.
+func (s *AsymSigner) Sign(params map[string]any) (string, error) {
+	if params == nil {
+		return "", fmt.Errorf("params cannot be nil")
+	}
+
+	sigString := buildSignatureString(params)
+
+	var sig []byte
+	var err error
+	switch s.spec.Type {
+	case KeyTypeEd25519:
+		sig, err = s.key.Sign(rand.Reader, []byte(sigString), crypto.Hash(0))
+	case KeyTypeRSA:
+		digest := sha256.Sum256([]byte(sigString))
+		sig, err = s.key.Sign(rand.Reader, digest[:], &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       crypto.SHA256,
+		})
+	case KeyTypeECDSA:
+		digest := sha256.Sum256([]byte(sigString))
+		sig, err = s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return "", fmt.Errorf("signature: unsupported key type %v", s.spec.Type)
+	}
+	if err != nil {
+		return "", fmt.Errorf("signature: sign: %w", err)
+	}
+
+	return hex.EncodeToString(sig), nil
+}
+
+// AsymVerifier validates signatures produced by an AsymSigner using the
+// corresponding public key.
+type AsymVerifier struct {
+	pub  crypto.PublicKey
+	spec KeySpec
+}
+
+// NewAsymVerifier creates an AsymVerifier from a public key. The supported
+// concrete types are ed25519.PublicKey, *rsa.PublicKey, and
+// *ecdsa.PublicKey, matching the private key types accepted by
+// NewAsymSigner.
+func NewAsymVerifier(pubKey crypto.PublicKey) (*AsymVerifier, error) {
+	spec, err := keySpecFromPublicKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &AsymVerifier{pub: pubKey, spec: spec}, nil
+}
+
+// KeySpec returns the KeySpec of the verifier's public key.
+func (v *AsymVerifier) KeySpec() KeySpec {
+	return v.spec
+}
+
+// Verify validates a hex-encoded asymmetric signature against the
+// provided parameters. Returns true if the signature is valid.
+func (v *AsymVerifier) Verify(params map[string]any, signature string) (bool, error) {
+	if params == nil {
+		return false, fmt.Errorf("params cannot be nil")
+	}
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false, fmt.Errorf("signature: decode: %w", err)
+	}
+
+	sigString := buildSignatureString(params)
+
+	switch v.spec.Type {
+	case KeyTypeEd25519:
+		return ed25519.Verify(v.pub.(ed25519.PublicKey), []byte(sigString), sig), nil
+	case KeyTypeRSA:
+		digest := sha256.Sum256([]byte(sigString))
+		err := rsa.VerifyPSS(v.pub.(*rsa.PublicKey), crypto.SHA256, digest[:], sig, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+		})
+		return err == nil, nil
+	case KeyTypeECDSA:
+		digest := sha256.Sum256([]byte(sigString))
+		return ecdsa.VerifyASN1(v.pub.(*ecdsa.PublicKey), digest[:], sig), nil
+	default:
+		return false, fmt.Errorf("signature: unsupported key type %v", v.spec.Type)
+	}
+}
+
+// JWK is a minimal JSON Web Key representation, sufficient for a server to
+// expose its verification key at a well-known endpoint so that callers of
+// AsymSigner can fetch it without an out-of-band exchange.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Alg string `json:"alg,omitempty"`
+}
+
+// PublicKeyJWK returns the verifier's public key encoded as a JWK, suitable
+// for serving at a public-key-distribution endpoint.
+func (v *AsymVerifier) PublicKeyJWK() (*JWK, error) {
+	switch key := v.pub.(type) {
+	case ed25519.PublicKey:
+		return &JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+			Alg: "EdDSA",
+		}, nil
+	case *rsa.PublicKey:
+		return &JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			Alg: "PS256",
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		return &JWK{
+			Kty: "EC",
+			Crv: key.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size))),
+			Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size))),
+			Alg: "ES256",
+		}, nil
+	default:
+		return nil, fmt.Errorf("signature: unsupported public key type %T", v.pub)
+	}
+}
+
+// ParsePrivateKeyPEM parses a PEM-encoded PKCS8 private key and returns it
+// as a crypto.Signer usable with NewAsymSigner.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signature: no PEM block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("signature: parse PKCS8 private key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("signature: key type %T does not implement crypto.Signer", key)
+	}
+	return signer, nil
+}
+
+// MarshalPrivateKeyPEM encodes a private key as a PEM-wrapped PKCS8 block.
+func MarshalPrivateKeyPEM(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("signature: marshal PKCS8 private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// ParsePublicKeyPEM parses a PEM-encoded PKIX public key.
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signature: no PEM block found")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("signature: parse PKIX public key: %w", err)
+	}
+	return pub, nil
+}
+
+// MarshalPublicKeyPEM encodes a public key as a PEM-wrapped PKIX block.
+func MarshalPublicKeyPEM(pub crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("signature: marshal PKIX public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
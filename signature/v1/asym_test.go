@@ -0,0 +1,194 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+)
+
+func TestAsymSigner_Ed25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	signer, err := NewAsymSigner(priv)
+	if err != nil {
+		t.Fatalf("NewAsymSigner: %v", err)
+	}
+	if signer.KeySpec().Type != KeyTypeEd25519 {
+		t.Fatalf("expected KeyTypeEd25519, got %v", signer.KeySpec().Type)
+	}
+
+	params := map[string]any{"action": "create", "user_id": 42}
+	sig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	verifier, err := NewAsymVerifier(pub)
+	if err != nil {
+		t.Fatalf("NewAsymVerifier: %v", err)
+	}
+	valid, err := verifier.Verify(params, sig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !valid {
+		t.Errorf("expected valid signature")
+	}
+
+	valid, err = verifier.Verify(map[string]any{"action": "delete"}, sig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if valid {
+		t.Errorf("signature verified with modified params")
+	}
+}
+
+func TestAsymSigner_RSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	signer, err := NewAsymSigner(priv)
+	if err != nil {
+		t.Fatalf("NewAsymSigner: %v", err)
+	}
+
+	params := map[string]any{"amount": 99.99}
+	sig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	verifier, err := NewAsymVerifier(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("NewAsymVerifier: %v", err)
+	}
+	valid, err := verifier.Verify(params, sig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !valid {
+		t.Errorf("expected valid signature")
+	}
+}
+
+func TestAsymSigner_RSA_UnsupportedSize(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	if _, err := NewAsymSigner(priv); err == nil {
+		t.Errorf("expected error for unsupported RSA key size")
+	}
+}
+
+func TestAsymSigner_ECDSA(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	signer, err := NewAsymSigner(priv)
+	if err != nil {
+		t.Fatalf("NewAsymSigner: %v", err)
+	}
+
+	params := map[string]any{"action": "query"}
+	sig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	verifier, err := NewAsymVerifier(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("NewAsymVerifier: %v", err)
+	}
+	valid, err := verifier.Verify(params, sig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !valid {
+		t.Errorf("expected valid signature")
+	}
+}
+
+func TestAsymVerifier_PublicKeyJWK(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	verifier, err := NewAsymVerifier(pub)
+	if err != nil {
+		t.Fatalf("NewAsymVerifier: %v", err)
+	}
+
+	jwk, err := verifier.PublicKeyJWK()
+	if err != nil {
+		t.Fatalf("PublicKeyJWK: %v", err)
+	}
+	if jwk.Kty != "OKP" || jwk.Crv != "Ed25519" || jwk.X == "" {
+		t.Errorf("unexpected JWK: %+v", jwk)
+	}
+}
+
+func TestPrivateKeyPEMRoundTrip(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	pemBytes, err := MarshalPrivateKeyPEM(priv)
+	if err != nil {
+		t.Fatalf("MarshalPrivateKeyPEM: %v", err)
+	}
+
+	parsed, err := ParsePrivateKeyPEM(pemBytes)
+	if err != nil {
+		t.Fatalf("ParsePrivateKeyPEM: %v", err)
+	}
+	if !parsed.Public().(ed25519.PublicKey).Equal(priv.Public().(ed25519.PublicKey)) {
+		t.Errorf("round-tripped key does not match original")
+	}
+}
+
+func TestPublicKeyPEMRoundTrip(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	pemBytes, err := MarshalPublicKeyPEM(pub)
+	if err != nil {
+		t.Fatalf("MarshalPublicKeyPEM: %v", err)
+	}
+
+	parsed, err := ParsePublicKeyPEM(pemBytes)
+	if err != nil {
+		t.Fatalf("ParsePublicKeyPEM: %v", err)
+	}
+	if !parsed.(ed25519.PublicKey).Equal(pub) {
+		t.Errorf("round-tripped key does not match original")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Canonicalizer builds the canonical string that gets signed from a set of
+// request parameters. Signer uses RawCanonicalizer by default; callers that
+// need parameter values to survive being carried in a URL or that need to
+// sign a real HTTP request should select a different implementation via
+// WithCanonicalizer. Canonicalize must return an error rather than silently
+// dropping a param it cannot represent (e.g. a NaN float or an unsupported
+// nested type) — Sign treats a dropped-without-error param as indistinguishable
+// from one that was never set, which would let it go unsigned.
+type Canonicalizer interface {
+	Canonicalize(params map[string]any) (string, error)
+}
+
+// RawCanonicalizer reproduces the original, unencoded Signer behavior:
+// key=value pairs joined with &, sorted by key, with no escaping. It breaks
+// if a value contains '&' or '=', but is kept as the default for backward
+// compatibility with existing signatures.
+type RawCanonicalizer struct{}
+
+// Canonicalize implements Canonicalizer.
+func (RawCanonicalizer) Canonicalize(params map[string]any) (string, error) {
+	return buildSignatureString(params), nil
+}
+
+// URLEncodedCanonicalizer percent-encodes keys and values per RFC 3986
+// before joining them, so that values containing '&', '=', or other
+// reserved characters round-trip safely through a query string.
+type URLEncodedCanonicalizer struct{}
+
+// Canonicalize implements Canonicalizer.
+func (URLEncodedCanonicalizer) Canonicalize(params map[string]any) (string, error) {
+	keys := make([]string, 0, len(params))
+	for k, v := range params {
+		if v == nil || v == "" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		strValue := valueToString(params[k])
+		if strValue == "" {
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%s", percentEncodeRFC3986(k), percentEncodeRFC3986(strValue)))
+	}
+
+	return strings.Join(pairs, "&"), nil
+}
+
+// percentEncodeRFC3986 percent-encodes s using RFC 3986's unreserved
+// character set (A-Z a-z 0-9 - _ . ~), emitting uppercase hex escapes for
+// everything else. Unlike url.QueryEscape, it does not treat spaces
+// specially, matching the encoding AWS SigV4 expects.
+func percentEncodeRFC3986(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUnreservedRFC3986(c) {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func isUnreservedRFC3986(c byte) bool {
+	switch {
+	case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		return true
+	case c == '-' || c == '_' || c == '.' || c == '~':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestURLEncodedCanonicalizer(t *testing.T) {
+	c := URLEncodedCanonicalizer{}
+
+	got, err := c.Canonicalize(map[string]any{
+		"redirect": "https://example.com/a&b=c",
+		"action":   "create",
+	})
+	if err != nil {
+		t.Fatalf("Canonicalize: %v", err)
+	}
+	want := "action=create&redirect=https%3A%2F%2Fexample.com%2Fa%26b%3Dc"
+	if got != want {
+		t.Errorf("Canonicalize() = %q, want %q", got, want)
+	}
+}
+
+func TestSigner_WithCanonicalizer_URLEncoded(t *testing.T) {
+	signer := NewSigner("test-secret", WithCanonicalizer(URLEncodedCanonicalizer{}))
+	params := map[string]any{"redirect": "a&b=c"}
+
+	sig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	valid, err := signer.Verify(params, sig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !valid {
+		t.Errorf("expected valid signature")
+	}
+
+	// The default RawCanonicalizer treats '&'/'=' inside a value as part of
+	// the signature string, so it must disagree with the URL-encoded form.
+	rawSigner := NewSigner("test-secret")
+	rawSig, _ := rawSigner.Sign(params)
+	if rawSig == sig {
+		t.Errorf("expected URL-encoded signature to differ from raw signature")
+	}
+}
+
+func TestSigner_SignRequest_Default(t *testing.T) {
+	signer := NewSigner("test-secret")
+	req, err := http.NewRequest(http.MethodGet, "https://api.seaverse.ai/v1/accounts?action=list", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	authHeader, err := signer.SignRequest(req)
+	if err != nil {
+		t.Fatalf("SignRequest: %v", err)
+	}
+	if !strings.HasPrefix(authHeader, "SEAVERSE-HMAC-SHA256 Signature=") {
+		t.Errorf("unexpected Authorization header: %s", authHeader)
+	}
+	if req.Header.Get("Authorization") != authHeader {
+		t.Errorf("Authorization header not set on request")
+	}
+}
+
+func TestSigner_SignRequest_SigV4(t *testing.T) {
+	signer := NewSigner("test-secret", WithCanonicalizer(SigV4Canonicalizer{
+		Region:  "us-east-1",
+		Service: "seaverse",
+	}))
+	req, err := http.NewRequest(http.MethodPost, "https://api.seaverse.ai/v1/accounts", strings.NewReader(`{"foo":"bar"}`))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Host = "api.seaverse.ai"
+
+	authHeader, err := signer.SignRequest(req)
+	if err != nil {
+		t.Fatalf("SignRequest: %v", err)
+	}
+	if !strings.HasPrefix(authHeader, "SEAVERSE4-HMAC-SHA256 Credential=") {
+		t.Errorf("unexpected Authorization header: %s", authHeader)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("reading body after SignRequest: %v", err)
+	}
+	if string(body) != `{"foo":"bar"}` {
+		t.Errorf("request body was not restored, got %q", body)
+	}
+}
+
+// TestSigner_SignRequest_SigV4_BindsHost verifies that the signed "host"
+// header actually reflects req.Host, so tampering with the Host on an
+// otherwise-identical request invalidates the signature instead of being
+// silently ignored.
+func TestSigner_SignRequest_SigV4_BindsHost(t *testing.T) {
+	newSignedHeader := func(host string) string {
+		signer := NewSigner("test-secret", WithCanonicalizer(SigV4Canonicalizer{
+			Region:  "us-east-1",
+			Service: "seaverse",
+		}))
+		req, err := http.NewRequest(http.MethodPost, "https://api.seaverse.ai/v1/accounts", strings.NewReader(`{"foo":"bar"}`))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		req.Host = host
+		req.Header.Set("X-Seaverse-Date", "20260725T120000Z")
+
+		authHeader, err := signer.SignRequest(req)
+		if err != nil {
+			t.Fatalf("SignRequest: %v", err)
+		}
+		return authHeader
+	}
+
+	legit := newSignedHeader("api.seaverse.ai")
+	tampered := newSignedHeader("evil.attacker.com")
+	if legit == tampered {
+		t.Errorf("expected signatures to differ when req.Host differs, got identical header %q", legit)
+	}
+}
+
+func TestCanonicalQuery_DoesNotDoubleEncode(t *testing.T) {
+	got := canonicalQuery("redirect=a%26b")
+	want := "redirect=a%26b"
+	if got != want {
+		t.Errorf("canonicalQuery() = %q, want %q", got, want)
+	}
+}
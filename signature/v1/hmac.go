@@ -25,17 +25,35 @@ import (
 
 // Signer provides HMAC-SHA256 signature generation and verification.
 type Signer struct {
-	secret string
+	secret        string
+	canonicalizer Canonicalizer
+}
+
+// SignerOption configures optional Signer behavior.
+type SignerOption func(*Signer)
+
+// WithCanonicalizer overrides the Canonicalizer used to build the string
+// that gets HMAC'd. The default is RawCanonicalizer, which reproduces the
+// original unencoded key=value&... behavior.
+func WithCanonicalizer(c Canonicalizer) SignerOption {
+	return func(s *Signer) {
+		s.canonicalizer = c
+	}
 }
 
 // NewSigner creates a new Signer with the provided secret key.
 //
 // The secret key will be used as the HMAC key for all signing operations.
 // Keep the secret key secure and never expose it in client-side code or public repositories.
-func NewSigner(secret string) *Signer {
-	return &Signer{
-		secret: secret,
+func NewSigner(secret string, opts ...SignerOption) *Signer {
+	s := &Signer{
+		secret:        secret,
+		canonicalizer: RawCanonicalizer{},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Sign generates a HMAC-SHA256 signature from the provided parameters.
@@ -43,7 +61,10 @@ func NewSigner(secret string) *Signer {
 // Signature generation follows these rules:
 //   - Encoding: UTF-8
 //   - Sorting: keys sorted alphabetically (a-z)
-//   - Special characters: use original values without URL encoding
+//   - Special characters: governed by the Signer's Canonicalizer; the
+//     default RawCanonicalizer uses original values without URL encoding,
+//     use WithCanonicalizer(URLEncodedCanonicalizer{}) for RFC 3986
+//     percent-encoding
 //   - Empty values: empty strings, nil values are excluded from signature
 //   - Numbers: converted to strings
 //   - Format: key1=value1&key2=value2
@@ -68,7 +89,10 @@ func (s *Signer) Sign(params map[string]any) (string, error) {
 	}
 
 	// Build the signature string
-	sigString := s.buildSignatureString(params)
+	sigString, err := s.canonicalizer.Canonicalize(params)
+	if err != nil {
+		return "", fmt.Errorf("signature: canonicalize params: %w", err)
+	}
 
 	// Generate HMAC-SHA256
 	h := hmac.New(sha256.New, []byte(s.secret))
@@ -107,7 +131,7 @@ func (s *Signer) Verify(params map[string]any, signature string) (bool, error) {
 // 2. Skip nil, empty string values
 // 3. Convert all values to strings
 // 4. Join with & separator in key=value format
-func (s *Signer) buildSignatureString(params map[string]any) string {
+func buildSignatureString(params map[string]any) string {
 	// Extract and sort keys
 	keys := make([]string, 0, len(params))
 	for k, v := range params {
@@ -123,7 +147,7 @@ func (s *Signer) buildSignatureString(params map[string]any) string {
 	pairs := make([]string, 0, len(keys))
 	for _, k := range keys {
 		v := params[k]
-		strValue := s.valueToString(v)
+		strValue := valueToString(v)
 
 		// Skip if converted value is empty
 		if strValue == "" {
@@ -145,7 +169,7 @@ func (s *Signer) buildSignatureString(params map[string]any) string {
 }
 
 // valueToString converts various types to string for signature generation.
-func (s *Signer) valueToString(v any) string {
+func valueToString(v any) string {
 	if v == nil {
 		return ""
 	}
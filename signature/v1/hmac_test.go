@@ -194,8 +194,6 @@ func TestSigner_Verify(t *testing.T) {
 }
 
 func TestSigner_BuildSignatureString(t *testing.T) {
-	signer := NewSigner("test")
-
 	tests := []struct {
 		name     string
 		params   map[string]any
@@ -241,7 +239,7 @@ func TestSigner_BuildSignatureString(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := signer.buildSignatureString(tt.params)
+			got := buildSignatureString(tt.params)
 			if got != tt.expected {
 				t.Errorf("buildSignatureString() = %q, want %q", got, tt.expected)
 			}
@@ -250,8 +248,6 @@ func TestSigner_BuildSignatureString(t *testing.T) {
 }
 
 func TestSigner_ValueToString(t *testing.T) {
-	signer := NewSigner("test")
-
 	tests := []struct {
 		name     string
 		value    any
@@ -273,7 +269,7 @@ func TestSigner_ValueToString(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := signer.valueToString(tt.value)
+			got := valueToString(tt.value)
 			if got != tt.expected {
 				t.Errorf("valueToString() = %q, want %q", got, tt.expected)
 			}
@@ -0,0 +1,263 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueEncoder converts a single param value into its canonical string
+// representation before it is joined into the signature string. It
+// replaces valueToString's strconv/fmt.Sprintf fallback for callers that
+// need portable, cross-language output for nested maps, slices,
+// json.Number, time.Time, and []byte.
+type ValueEncoder interface {
+	// Encode returns the canonical string form of v. Returning an error
+	// fails the whole Canonicalize call (and so Sign and Verify); the
+	// param is never silently dropped the way nil and empty-string values
+	// are.
+	Encode(v any) (string, error)
+}
+
+// JCSEncoder encodes values following RFC 8785 (the JSON Canonicalization
+// Scheme): object keys are sorted by UTF-8 code point, strings use JSON
+// string escaping, numbers use the shortest round-tripping decimal form
+// (matching ECMAScript's Number.prototype.toString for the common finite
+// range), time.Time is RFC 3339 with nanosecond precision in UTC, and
+// []byte is standard base64. Use it via WithValueEncoder(signature.JCSEncoder)
+// so that a Python or JS peer signing the same payload produces the same
+// digest.
+var JCSEncoder ValueEncoder = jcsEncoder{}
+
+type jcsEncoder struct{}
+
+func (jcsEncoder) Encode(v any) (string, error) {
+	return encodeJCSValue(v)
+}
+
+func encodeJCSValue(v any) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case string:
+		return encodeJCSString(val), nil
+	case json.Number:
+		f, err := val.Float64()
+		if err != nil {
+			return "", fmt.Errorf("signature: invalid json.Number %q: %w", val, err)
+		}
+		return encodeJCSFloat(f, 64)
+	case time.Time:
+		return encodeJCSString(val.UTC().Format(time.RFC3339Nano)), nil
+	case []byte:
+		return encodeJCSString(base64.StdEncoding.EncodeToString(val)), nil
+	case int:
+		return strconv.FormatInt(int64(val), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(val), 10), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(val), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(val), 10), nil
+	case uint64:
+		return strconv.FormatUint(val, 10), nil
+	case float32:
+		return encodeJCSFloat(float64(val), 32)
+	case float64:
+		return encodeJCSFloat(val, 64)
+	case map[string]any:
+		return encodeJCSObject(val)
+	case []any:
+		return encodeJCSArray(val)
+	default:
+		return "", fmt.Errorf("signature: JCSEncoder: unsupported value type %T", v)
+	}
+}
+
+func encodeJCSObject(m map[string]any) (string, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		encoded, err := encodeJCSValue(m[k])
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(encodeJCSString(k))
+		b.WriteByte(':')
+		b.WriteString(encoded)
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+func encodeJCSArray(items []any) (string, error) {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		encoded, err := encodeJCSValue(item)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(encoded)
+	}
+	b.WriteByte(']')
+	return b.String(), nil
+}
+
+// encodeJCSString quotes and escapes s the way JSON.stringify would:
+// '"', '\\', and control characters are escaped, everything else
+// (including multi-byte UTF-8) passes through unescaped.
+func encodeJCSString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// encodeJCSFloat formats f using the shortest decimal representation that
+// round-trips at the given bitSize (32 for float32, 64 for float64),
+// matching ECMAScript Number.prototype.toString for the finite, non-NaN
+// range: plain decimal notation between 1e-6 and 1e21, exponential
+// notation (lowercase 'e', explicit sign, no leading zeros in the
+// exponent) outside it. bitSize must match the precision f actually came
+// from (e.g. float64(float32Value), 32) so a float32 input round-trips
+// to its original value instead of reproducing float64 conversion noise.
+func encodeJCSFloat(f float64, bitSize int) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("signature: cannot encode non-finite number %v", f)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+
+	abs := math.Abs(f)
+	if abs >= 1e21 || abs < 1e-6 {
+		return normalizeJCSExponent(strconv.FormatFloat(f, 'e', -1, bitSize)), nil
+	}
+	return strconv.FormatFloat(f, 'f', -1, bitSize), nil
+}
+
+func normalizeJCSExponent(s string) string {
+	idx := strings.IndexByte(s, 'e')
+	if idx < 0 {
+		return s
+	}
+	mantissa, exp := s[:idx], s[idx+1:]
+
+	sign := "+"
+	if len(exp) > 0 && (exp[0] == '+' || exp[0] == '-') {
+		sign = string(exp[0])
+		exp = exp[1:]
+	}
+	exp = strings.TrimLeft(exp, "0")
+	if exp == "" {
+		exp = "0"
+	}
+	return mantissa + "e" + sign + exp
+}
+
+// valueEncoderCanonicalizer builds key=value&... pairs like
+// RawCanonicalizer, but converts each value through a pluggable
+// ValueEncoder instead of valueToString. WithValueEncoder constructs one
+// of these under the hood.
+type valueEncoderCanonicalizer struct {
+	encoder ValueEncoder
+}
+
+// Canonicalize implements Canonicalizer. An encoder error (e.g. a NaN/Inf
+// float, or an unsupported nested type) fails the whole Canonicalize call
+// rather than silently dropping that one param — a dropped param would sign
+// as if it were never set, letting it be added or changed after the fact
+// without invalidating the signature.
+func (c valueEncoderCanonicalizer) Canonicalize(params map[string]any) (string, error) {
+	keys := make([]string, 0, len(params))
+	for k, v := range params {
+		if v == nil || v == "" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		encoded, err := c.encoder.Encode(params[k])
+		if err != nil {
+			return "", fmt.Errorf("signature: encode param %q: %w", k, err)
+		}
+		if encoded == "" {
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, encoded))
+	}
+
+	return strings.Join(pairs, "&"), nil
+}
+
+// WithValueEncoder configures the Signer to convert each param value via
+// enc instead of the default valueToString, fixing the float and
+// collection edge cases that arise with maps, slices, json.Number,
+// time.Time, and []byte values. Pass signature.JCSEncoder for RFC 8785
+// (JCS) output.
+func WithValueEncoder(enc ValueEncoder) SignerOption {
+	return WithCanonicalizer(valueEncoderCanonicalizer{encoder: enc})
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestJCSEncoder_Encode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"nil", nil, "null"},
+		{"bool", true, "true"},
+		{"string", "hello \"world\"\n", `"hello \"world\"\n"`},
+		{"int", 42, "42"},
+		{"float plain", 78.9, "78.9"},
+		{"float integral", float64(100), "100"},
+		{"small float exponent", 1.5e-10, "1.5e-10"},
+		{"large float exponent", 1.5e21, "1.5e+21"},
+		{
+			"nested object sorted",
+			map[string]any{"b": 1, "a": "x"},
+			`{"a":"x","b":1}`,
+		},
+		{
+			"array",
+			[]any{1, "two", false},
+			`[1,"two",false]`,
+		},
+		{"bytes base64", []byte("hi"), `"aGk="`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JCSEncoder.Encode(tt.value)
+			if err != nil {
+				t.Fatalf("Encode(%v): %v", tt.value, err)
+			}
+			if got != tt.expected {
+				t.Errorf("Encode(%v) = %q, want %q", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJCSEncoder_TimeRFC3339Nanos(t *testing.T) {
+	ts := time.Date(2026, 7, 25, 12, 0, 0, 123000000, time.UTC)
+	got, err := JCSEncoder.Encode(ts)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	want := `"2026-07-25T12:00:00.123Z"`
+	if got != want {
+		t.Errorf("Encode(time) = %q, want %q", got, want)
+	}
+}
+
+func TestJCSEncoder_Float32Precision(t *testing.T) {
+	got, err := JCSEncoder.Encode(float32(3.14))
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	want := "3.14"
+	if got != want {
+		t.Errorf("Encode(float32(3.14)) = %q, want %q", got, want)
+	}
+}
+
+func TestJCSEncoder_JSONNumber(t *testing.T) {
+	got, err := JCSEncoder.Encode(json.Number("12.50"))
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if got != "12.5" {
+		t.Errorf("Encode(json.Number) = %q, want %q", got, "12.5")
+	}
+}
+
+func TestJCSEncoder_NonFiniteRejected(t *testing.T) {
+	if _, err := JCSEncoder.Encode(math.NaN()); err == nil {
+		t.Errorf("expected error encoding NaN")
+	}
+}
+
+func TestSigner_WithValueEncoder_JCS(t *testing.T) {
+	signer := NewSigner("test-secret", WithValueEncoder(JCSEncoder))
+
+	params := map[string]any{
+		"amount":  99.99,
+		"profile": map[string]any{"age": 30, "name": "alice"},
+	}
+
+	sig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	valid, err := signer.Verify(params, sig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !valid {
+		t.Errorf("expected valid signature")
+	}
+
+	// A differently-ordered but equal nested map must canonicalize to the
+	// same string and therefore produce the same signature.
+	reordered := map[string]any{
+		"amount":  99.99,
+		"profile": map[string]any{"name": "alice", "age": 30},
+	}
+	sig2, err := signer.Sign(reordered)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if sig != sig2 {
+		t.Errorf("expected deterministic signature regardless of map iteration order")
+	}
+}
+
+// TestSigner_WithValueEncoder_JCS_UnencodableParamFailsSign verifies that a
+// param the encoder can't represent (here, a NaN float) fails Sign outright
+// instead of silently being dropped from the signature — a dropped param
+// would let an attacker add or mutate it without invalidating the signature.
+func TestSigner_WithValueEncoder_JCS_UnencodableParamFailsSign(t *testing.T) {
+	signer := NewSigner("test-secret", WithValueEncoder(JCSEncoder))
+
+	_, err := signer.Sign(map[string]any{
+		"amount": 99.99,
+		"evil":   math.NaN(),
+	})
+	if err == nil {
+		t.Fatalf("expected Sign to fail when a param cannot be encoded")
+	}
+
+	validSig, err := signer.Sign(map[string]any{"amount": 99.99})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if validSig == "" {
+		t.Fatalf("expected a signature for the encodable-only params")
+	}
+}
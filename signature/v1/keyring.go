@@ -0,0 +1,291 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned when looking up a key in a KeyRing.
+var (
+	ErrUnknownKeyID = errors.New("signature: unknown key id")
+	ErrExpiredKeyID = errors.New("signature: key id is not currently valid")
+)
+
+// KeyEntry is a single secret in a KeyRing, scoped to an optional
+// validity window so a rotated-out key keeps verifying old signatures
+// until it expires.
+type KeyEntry struct {
+	KeyID     string
+	Secret    []byte
+	NotBefore time.Time // zero means no lower bound
+	NotAfter  time.Time // zero means no upper bound
+}
+
+// validAt reports whether the entry may be used to sign or verify at t.
+func (e KeyEntry) validAt(t time.Time) bool {
+	if !e.NotBefore.IsZero() && t.Before(e.NotBefore) {
+		return false
+	}
+	if !e.NotAfter.IsZero() && t.After(e.NotAfter) {
+		return false
+	}
+	return true
+}
+
+// KeyRing holds a set of HMAC secrets keyed by key ID, so a secret can be
+// rotated without invalidating signatures produced under the previous one.
+// The order in which entries were given is not kept; a later entry with the
+// same key ID replaces an earlier one. It is safe for concurrent use.
+type KeyRing struct {
+	mu      sync.RWMutex
+	entries map[string]KeyEntry
+	primary string
+}
+
+// NewKeyRing creates a KeyRing from the given entries. Call SetPrimary to
+// choose which key new signatures are produced with.
+func NewKeyRing(entries ...KeyEntry) *KeyRing {
+	r := &KeyRing{entries: make(map[string]KeyEntry, len(entries))}
+	for _, e := range entries {
+		r.entries[e.KeyID] = e
+	}
+	return r
+}
+
+// SetPrimary selects the key used to sign new messages. It returns
+// ErrUnknownKeyID if kid is not in the ring.
+func (r *KeyRing) SetPrimary(kid string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.entries[kid]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKeyID, kid)
+	}
+	r.primary = kid
+	return nil
+}
+
+// Primary returns the entry currently used to sign new messages.
+func (r *KeyRing) Primary() (KeyEntry, error) {
+	r.mu.RLock()
+	primary := r.primary
+	r.mu.RUnlock()
+	if primary == "" {
+		return KeyEntry{}, fmt.Errorf("signature: key ring has no primary key")
+	}
+	return r.Lookup(primary)
+}
+
+// Lookup returns the entry for kid, if it exists and is currently valid.
+func (r *KeyRing) Lookup(kid string) (KeyEntry, error) {
+	r.mu.RLock()
+	entry, ok := r.entries[kid]
+	r.mu.RUnlock()
+
+	if !ok {
+		return KeyEntry{}, fmt.Errorf("%w: %s", ErrUnknownKeyID, kid)
+	}
+	if !entry.validAt(time.Now()) {
+		return KeyEntry{}, fmt.Errorf("%w: %s", ErrExpiredKeyID, kid)
+	}
+	return entry, nil
+}
+
+// Reload replaces the ring's entries and primary key with the result of
+// calling provider.LoadKeys, for hot rotation without restarting the
+// process.
+func (r *KeyRing) Reload(ctx context.Context, provider KeyProvider) error {
+	entries, primary, err := provider.LoadKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("signature: reload key ring: %w", err)
+	}
+
+	next := make(map[string]KeyEntry, len(entries))
+	for _, e := range entries {
+		next[e.KeyID] = e
+	}
+
+	r.mu.Lock()
+	r.entries = next
+	r.primary = primary
+	r.mu.Unlock()
+	return nil
+}
+
+// KeyProvider loads a KeyRing's entries and primary key ID from an
+// external source. Implementations typically wrap env vars, files, AWS
+// Secrets Manager, or Vault; EnvKeyProvider is the bundled env-based one.
+type KeyProvider interface {
+	LoadKeys(ctx context.Context) (entries []KeyEntry, primaryKeyID string, err error)
+}
+
+// envKeyDocument is the JSON shape EnvKeyProvider expects, e.g.:
+//
+//	{
+//	  "primary": "2026-07",
+//	  "keys": [
+//	    {"kid": "2026-07", "secret": "..."},
+//	    {"kid": "2026-04", "secret": "...", "not_after": "2026-08-01T00:00:00Z"}
+//	  ]
+//	}
+type envKeyDocument struct {
+	Primary string `json:"primary"`
+	Keys    []struct {
+		KeyID     string    `json:"kid"`
+		Secret    string    `json:"secret"`
+		NotBefore time.Time `json:"not_before"`
+		NotAfter  time.Time `json:"not_after"`
+	} `json:"keys"`
+}
+
+// EnvKeyProvider loads a KeyRing from a single environment variable
+// holding a JSON document (see envKeyDocument). Getenv defaults to
+// os.Getenv and is overridable for tests.
+type EnvKeyProvider struct {
+	Var    string
+	Getenv func(string) string
+}
+
+// LoadKeys implements KeyProvider.
+func (p EnvKeyProvider) LoadKeys(ctx context.Context) ([]KeyEntry, string, error) {
+	getenv := p.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+
+	raw := getenv(p.Var)
+	if strings.TrimSpace(raw) == "" {
+		return nil, "", fmt.Errorf("signature: env var %s is empty", p.Var)
+	}
+
+	var doc envKeyDocument
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		return nil, "", fmt.Errorf("signature: parse %s: %w", p.Var, err)
+	}
+
+	entries := make([]KeyEntry, 0, len(doc.Keys))
+	for _, k := range doc.Keys {
+		entries = append(entries, KeyEntry{
+			KeyID:     k.KeyID,
+			Secret:    []byte(k.Secret),
+			NotBefore: k.NotBefore,
+			NotAfter:  k.NotAfter,
+		})
+	}
+	return entries, doc.Primary, nil
+}
+
+// RingSigner signs and verifies with a KeyRing instead of a single
+// secret, prefixing every signature with "kid=<id>:" so Verify knows
+// which secret to validate against. This is what lets a deployment
+// rotate its HMAC secret without invalidating signatures that are still
+// in flight.
+type RingSigner struct {
+	ring          *KeyRing
+	canonicalizer Canonicalizer
+}
+
+// RingSignerOption configures optional RingSigner behavior.
+type RingSignerOption func(*RingSigner)
+
+// WithRingCanonicalizer overrides the Canonicalizer used to build the
+// string that gets HMAC'd, mirroring Signer's WithCanonicalizer.
+func WithRingCanonicalizer(c Canonicalizer) RingSignerOption {
+	return func(s *RingSigner) { s.canonicalizer = c }
+}
+
+// NewRingSigner creates a RingSigner backed by ring.
+func NewRingSigner(ring *KeyRing, opts ...RingSignerOption) *RingSigner {
+	s := &RingSigner{ring: ring, canonicalizer: RawCanonicalizer{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Sign generates a kid-tagged HMAC-SHA256 signature using the ring's
+// current primary key, in "kid=<id>:<hex signature>" form.
+func (s *RingSigner) Sign(params map[string]any) (string, error) {
+	if params == nil {
+		return "", fmt.Errorf("params cannot be nil")
+	}
+
+	entry, err := s.ring.Primary()
+	if err != nil {
+		return "", err
+	}
+
+	sigString, err := s.canonicalizer.Canonicalize(params)
+	if err != nil {
+		return "", fmt.Errorf("signature: canonicalize params: %w", err)
+	}
+	sig := hmacSHA256(entry.Secret, sigString)
+
+	return fmt.Sprintf("kid=%s:%s", entry.KeyID, hex.EncodeToString(sig)), nil
+}
+
+// Verify validates a kid-tagged signature against params, looking up the
+// matching secret by the kid embedded in the signature and comparing in
+// constant time. It returns ErrUnknownKeyID or ErrExpiredKeyID (wrapped,
+// so errors.Is still matches) if the kid doesn't resolve to a usable key.
+func (s *RingSigner) Verify(params map[string]any, signature string) (bool, error) {
+	if params == nil {
+		return false, fmt.Errorf("params cannot be nil")
+	}
+
+	kid, sigHex, err := parseKIDSignature(signature)
+	if err != nil {
+		return false, err
+	}
+
+	entry, err := s.ring.Lookup(kid)
+	if err != nil {
+		return false, err
+	}
+
+	sigString, err := s.canonicalizer.Canonicalize(params)
+	if err != nil {
+		return false, fmt.Errorf("signature: canonicalize params: %w", err)
+	}
+	expected := hex.EncodeToString(hmacSHA256(entry.Secret, sigString))
+
+	return hmac.Equal([]byte(expected), []byte(sigHex)), nil
+}
+
+// parseKIDSignature splits a "kid=<id>:<hex>" signature into its key ID
+// and hex-encoded digest. It splits on the last ':' since the hex digest
+// never contains one, but a KeyID could (e.g. "prod:2026-07").
+func parseKIDSignature(signature string) (kid, sigHex string, err error) {
+	const prefix = "kid="
+	if !strings.HasPrefix(signature, prefix) {
+		return "", "", fmt.Errorf("signature: malformed kid-tagged signature: missing %q prefix", prefix)
+	}
+	rest := signature[len(prefix):]
+
+	idx := strings.LastIndexByte(rest, ':')
+	if idx < 0 {
+		return "", "", fmt.Errorf("signature: malformed kid-tagged signature: missing separator")
+	}
+	return rest[:idx], rest[idx+1:], nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRingSigner_SignAndVerify(t *testing.T) {
+	ring := NewKeyRing(
+		KeyEntry{KeyID: "2026-07", Secret: []byte("current-secret")},
+	)
+	if err := ring.SetPrimary("2026-07"); err != nil {
+		t.Fatalf("SetPrimary: %v", err)
+	}
+
+	signer := NewRingSigner(ring)
+	params := map[string]any{"action": "create"}
+
+	sig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if got, want := sig[:len("kid=2026-07:")], "kid=2026-07:"; got != want {
+		t.Errorf("expected signature to start with %q, got %q", want, sig)
+	}
+
+	valid, err := signer.Verify(params, sig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !valid {
+		t.Errorf("expected valid signature")
+	}
+}
+
+func TestRingSigner_RotationKeepsOldSignaturesValid(t *testing.T) {
+	ring := NewKeyRing(
+		KeyEntry{KeyID: "2026-04", Secret: []byte("old-secret")},
+	)
+	if err := ring.SetPrimary("2026-04"); err != nil {
+		t.Fatalf("SetPrimary: %v", err)
+	}
+	signer := NewRingSigner(ring)
+
+	params := map[string]any{"action": "create"}
+	oldSig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	// Rotate: add the new key and flip primary, but keep the old one
+	// around so in-flight signatures still verify.
+	if err := ring.Reload(context.Background(), staticKeyProvider{
+		entries: []KeyEntry{
+			{KeyID: "2026-04", Secret: []byte("old-secret")},
+			{KeyID: "2026-07", Secret: []byte("new-secret")},
+		},
+		primary: "2026-07",
+	}); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	valid, err := signer.Verify(params, oldSig)
+	if err != nil {
+		t.Fatalf("Verify old signature after rotation: %v", err)
+	}
+	if !valid {
+		t.Errorf("expected old signature to remain valid after rotation")
+	}
+
+	newSig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign after rotation: %v", err)
+	}
+	if newSig[:len("kid=2026-07:")] != "kid=2026-07:" {
+		t.Errorf("expected new signatures to use the rotated-in key, got %q", newSig)
+	}
+}
+
+func TestKeyRing_UnknownKeyID(t *testing.T) {
+	ring := NewKeyRing(KeyEntry{KeyID: "a", Secret: []byte("s")})
+	if err := ring.SetPrimary("a"); err != nil {
+		t.Fatalf("SetPrimary: %v", err)
+	}
+	signer := NewRingSigner(ring)
+
+	_, err := signer.Verify(map[string]any{"x": "y"}, "kid=unknown:deadbeef")
+	if !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("expected ErrUnknownKeyID, got %v", err)
+	}
+}
+
+func TestKeyRing_ExpiredKeyID(t *testing.T) {
+	ring := NewKeyRing(KeyEntry{
+		KeyID:    "a",
+		Secret:   []byte("s"),
+		NotAfter: time.Now().Add(-time.Hour),
+	})
+	_, err := ring.Lookup("a")
+	if !errors.Is(err, ErrExpiredKeyID) {
+		t.Errorf("expected ErrExpiredKeyID, got %v", err)
+	}
+}
+
+func TestParseKIDSignature_Malformed(t *testing.T) {
+	ring := NewKeyRing(KeyEntry{KeyID: "a", Secret: []byte("s")})
+	signer := NewRingSigner(ring)
+
+	if _, err := signer.Verify(map[string]any{"x": "y"}, "not-kid-tagged"); err == nil {
+		t.Errorf("expected error for signature missing kid= prefix")
+	}
+	if _, err := signer.Verify(map[string]any{"x": "y"}, "kid=a-no-colon"); err == nil {
+		t.Errorf("expected error for signature missing separator")
+	}
+}
+
+func TestRingSigner_KeyIDContainingColon(t *testing.T) {
+	ring := NewKeyRing(KeyEntry{KeyID: "prod:2026-07", Secret: []byte("s")})
+	if err := ring.SetPrimary("prod:2026-07"); err != nil {
+		t.Fatalf("SetPrimary: %v", err)
+	}
+	signer := NewRingSigner(ring)
+
+	params := map[string]any{"action": "create"}
+	sig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	valid, err := signer.Verify(params, sig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !valid {
+		t.Errorf("expected valid signature for a KeyID containing ':'")
+	}
+}
+
+func TestEnvKeyProvider_LoadKeys(t *testing.T) {
+	provider := EnvKeyProvider{
+		Var: "SEAVERSE_HMAC_KEYS",
+		Getenv: func(name string) string {
+			return `{"primary":"2026-07","keys":[{"kid":"2026-07","secret":"s1"},{"kid":"2026-04","secret":"s0"}]}`
+		},
+	}
+
+	entries, primary, err := provider.LoadKeys(context.Background())
+	if err != nil {
+		t.Fatalf("LoadKeys: %v", err)
+	}
+	if primary != "2026-07" {
+		t.Errorf("expected primary 2026-07, got %s", primary)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+}
+
+type staticKeyProvider struct {
+	entries []KeyEntry
+	primary string
+}
+
+func (p staticKeyProvider) LoadKeys(ctx context.Context) ([]KeyEntry, string, error) {
+	return p.entries, p.primary, nil
+}
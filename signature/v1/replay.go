@@ -0,0 +1,250 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Errors returned by Signer.VerifyWithPolicy. Use errors.Is to test for
+// them; ErrMissingRequiredParam is wrapped with the offending param name.
+var (
+	ErrStaleTimestamp       = errors.New("signature: timestamp outside allowed clock skew")
+	ErrReplayedNonce        = errors.New("signature: nonce has already been used")
+	ErrMissingRequiredParam = errors.New("signature: missing required param")
+)
+
+// NonceStore tracks nonces that have already been used so that
+// VerifyWithPolicy can reject replayed requests. Seen must atomically
+// record nonce if it has not been seen before, and report whether it had
+// already been recorded.
+type NonceStore interface {
+	// Seen records nonce as used for ttl and reports true if nonce was
+	// already present (i.e. this call observed a replay).
+	Seen(nonce string, ttl time.Duration) (bool, error)
+}
+
+// Policy configures the replay-protection checks applied by
+// Signer.VerifyWithPolicy on top of the normal signature check.
+type Policy struct {
+	// MaxClockSkew is the maximum allowed difference between the request's
+	// "timestamp" param and the current time. Zero disables the
+	// timestamp check.
+	MaxClockSkew time.Duration
+	// RequiredParams lists params that must be present (regardless of
+	// value) for verification to proceed, e.g. "timestamp", "nonce".
+	RequiredParams []string
+	// NonceStore, if set, is used to reject requests that reuse a
+	// "nonce" param already seen within NonceTTL.
+	NonceStore NonceStore
+	// NonceTTL is how long a nonce is remembered. Defaults to
+	// 2*MaxClockSkew when zero and MaxClockSkew is set, and to
+	// defaultNonceTTL when both are zero, so a NonceStore configured
+	// without a clock-skew check still remembers nonces for a sane
+	// duration instead of evicting them immediately.
+	NonceTTL time.Duration
+}
+
+// defaultNonceTTL is the nonce TTL used when a Policy sets NonceStore but
+// leaves both NonceTTL and MaxClockSkew at zero.
+const defaultNonceTTL = 5 * time.Minute
+
+// VerifyWithPolicy validates sig against params like Verify, and
+// additionally enforces policy's required params, timestamp freshness,
+// and nonce replay protection.
+//
+// Example:
+//
+//	policy := signature.Policy{
+//	    MaxClockSkew:   5 * time.Minute,
+//	    RequiredParams: []string{"timestamp", "nonce"},
+//	    NonceStore:     signature.NewMemoryNonceStore(10000),
+//	}
+//	valid, err := signer.VerifyWithPolicy(params, sig, policy)
+//	if errors.Is(err, signature.ErrReplayedNonce) {
+//	    // reject: replay attack
+//	}
+func (s *Signer) VerifyWithPolicy(params map[string]any, sig string, policy Policy) (bool, error) {
+	for _, name := range policy.RequiredParams {
+		if _, ok := params[name]; !ok {
+			return false, fmt.Errorf("%w: %s", ErrMissingRequiredParam, name)
+		}
+	}
+
+	if policy.MaxClockSkew > 0 {
+		ts, ok := params["timestamp"]
+		if !ok {
+			return false, fmt.Errorf("%w: timestamp", ErrMissingRequiredParam)
+		}
+		requestTime, err := parseTimestamp(ts)
+		if err != nil {
+			return false, fmt.Errorf("signature: parse timestamp: %w", err)
+		}
+		if skew := time.Since(requestTime); skew > policy.MaxClockSkew || skew < -policy.MaxClockSkew {
+			return false, ErrStaleTimestamp
+		}
+	}
+
+	valid, err := s.Verify(params, sig)
+	if err != nil || !valid {
+		return valid, err
+	}
+
+	if policy.NonceStore != nil {
+		nonce, ok := params["nonce"].(string)
+		if !ok || nonce == "" {
+			return false, fmt.Errorf("%w: nonce", ErrMissingRequiredParam)
+		}
+
+		ttl := policy.NonceTTL
+		if ttl <= 0 {
+			if policy.MaxClockSkew > 0 {
+				ttl = 2 * policy.MaxClockSkew
+			} else {
+				ttl = defaultNonceTTL
+			}
+		}
+
+		seen, err := policy.NonceStore.Seen(nonce, ttl)
+		if err != nil {
+			return false, fmt.Errorf("signature: nonce store: %w", err)
+		}
+		if seen {
+			return false, ErrReplayedNonce
+		}
+	}
+
+	return true, nil
+}
+
+// parseTimestamp converts a "timestamp" param (an epoch-seconds int or
+// string, as produced by the various language SDKs) into a time.Time.
+func parseTimestamp(v any) (time.Time, error) {
+	switch val := v.(type) {
+	case string:
+		sec, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp %q: %w", val, err)
+		}
+		return time.Unix(sec, 0), nil
+	case int:
+		return time.Unix(int64(val), 0), nil
+	case int32:
+		return time.Unix(int64(val), 0), nil
+	case int64:
+		return time.Unix(val, 0), nil
+	case float64:
+		return time.Unix(int64(val), 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported timestamp type %T", v)
+	}
+}
+
+// MemoryNonceStore is an in-memory, capacity-bounded NonceStore. Entries
+// are evicted least-recently-used once capacity is exceeded, and expired
+// entries are treated as unseen (and refreshed) the next time they're
+// checked. It is safe for concurrent use.
+type MemoryNonceStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type nonceEntry struct {
+	nonce     string
+	expiresAt time.Time
+}
+
+// NewMemoryNonceStore creates a MemoryNonceStore holding at most capacity
+// nonces at a time.
+func NewMemoryNonceStore(capacity int) *MemoryNonceStore {
+	return &MemoryNonceStore{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Seen implements NonceStore.
+func (m *MemoryNonceStore) Seen(nonce string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if el, ok := m.items[nonce]; ok {
+		entry := el.Value.(*nonceEntry)
+		if now.Before(entry.expiresAt) {
+			m.order.MoveToFront(el)
+			return true, nil
+		}
+		entry.expiresAt = now.Add(ttl)
+		m.order.MoveToFront(el)
+		return false, nil
+	}
+
+	el := m.order.PushFront(&nonceEntry{nonce: nonce, expiresAt: now.Add(ttl)})
+	m.items[nonce] = el
+
+	for m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		if oldest == nil {
+			break
+		}
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*nonceEntry).nonce)
+	}
+
+	return false, nil
+}
+
+// RedisClient is the minimal surface RedisNonceStore needs from a Redis
+// client, so this package can stay driver-agnostic. Wrap whichever client
+// your deployment already uses (go-redis, redigo, ...) to satisfy it,
+// typically via `SETNX key "" EX ttl`.
+type RedisClient interface {
+	// SetNX sets key if it does not already exist, with the given
+	// expiration, and reports whether it was newly set.
+	SetNX(key string, ttl time.Duration) (ok bool, err error)
+}
+
+// RedisNonceStore is a NonceStore backed by a Redis-compatible client,
+// for deployments with multiple verifying instances that need to share
+// nonce state.
+type RedisNonceStore struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedisNonceStore creates a RedisNonceStore that namespaces keys with
+// prefix (e.g. "seaverse:nonce:") to avoid colliding with unrelated keys
+// in a shared Redis instance.
+func NewRedisNonceStore(client RedisClient, prefix string) *RedisNonceStore {
+	return &RedisNonceStore{client: client, prefix: prefix}
+}
+
+// Seen implements NonceStore.
+func (r *RedisNonceStore) Seen(nonce string, ttl time.Duration) (bool, error) {
+	ok, err := r.client.SetNX(r.prefix+nonce, ttl)
+	if err != nil {
+		return false, fmt.Errorf("signature: redis nonce check: %w", err)
+	}
+	return !ok, nil
+}
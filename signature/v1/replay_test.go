@@ -0,0 +1,198 @@
+// Copyright 2026 SeaVerse AI
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func freshParams() map[string]any {
+	return map[string]any{
+		"action":    "create",
+		"timestamp": strconv.FormatInt(time.Now().Unix(), 10),
+		"nonce":     "abc123",
+	}
+}
+
+func TestSigner_VerifyWithPolicy_Success(t *testing.T) {
+	signer := NewSigner("test-secret")
+	params := freshParams()
+	sig, err := signer.Sign(params)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	policy := Policy{
+		MaxClockSkew:   5 * time.Minute,
+		RequiredParams: []string{"timestamp", "nonce"},
+		NonceStore:     NewMemoryNonceStore(100),
+	}
+
+	valid, err := signer.VerifyWithPolicy(params, sig, policy)
+	if err != nil {
+		t.Fatalf("VerifyWithPolicy: %v", err)
+	}
+	if !valid {
+		t.Errorf("expected valid signature")
+	}
+}
+
+func TestSigner_VerifyWithPolicy_MissingParam(t *testing.T) {
+	signer := NewSigner("test-secret")
+	params := map[string]any{"action": "create"}
+	sig, _ := signer.Sign(params)
+
+	_, err := signer.VerifyWithPolicy(params, sig, Policy{RequiredParams: []string{"nonce"}})
+	if !errors.Is(err, ErrMissingRequiredParam) {
+		t.Errorf("expected ErrMissingRequiredParam, got %v", err)
+	}
+}
+
+func TestSigner_VerifyWithPolicy_StaleTimestamp(t *testing.T) {
+	signer := NewSigner("test-secret")
+	params := map[string]any{
+		"action":    "create",
+		"timestamp": strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10),
+	}
+	sig, _ := signer.Sign(params)
+
+	_, err := signer.VerifyWithPolicy(params, sig, Policy{MaxClockSkew: 5 * time.Minute})
+	if !errors.Is(err, ErrStaleTimestamp) {
+		t.Errorf("expected ErrStaleTimestamp, got %v", err)
+	}
+}
+
+func TestSigner_VerifyWithPolicy_ReplayedNonce(t *testing.T) {
+	signer := NewSigner("test-secret")
+	params := freshParams()
+	sig, _ := signer.Sign(params)
+
+	policy := Policy{
+		MaxClockSkew: 5 * time.Minute,
+		NonceStore:   NewMemoryNonceStore(100),
+	}
+
+	if _, err := signer.VerifyWithPolicy(params, sig, policy); err != nil {
+		t.Fatalf("first verify: %v", err)
+	}
+
+	_, err := signer.VerifyWithPolicy(params, sig, policy)
+	if !errors.Is(err, ErrReplayedNonce) {
+		t.Errorf("expected ErrReplayedNonce, got %v", err)
+	}
+}
+
+func TestSigner_VerifyWithPolicy_ReplayedNonce_NoMaxClockSkew(t *testing.T) {
+	signer := NewSigner("test-secret")
+	params := freshParams()
+	sig, _ := signer.Sign(params)
+
+	// NonceStore without MaxClockSkew or NonceTTL must still remember the
+	// nonce instead of the TTL collapsing to zero.
+	policy := Policy{
+		NonceStore: NewMemoryNonceStore(100),
+	}
+
+	if _, err := signer.VerifyWithPolicy(params, sig, policy); err != nil {
+		t.Fatalf("first verify: %v", err)
+	}
+
+	_, err := signer.VerifyWithPolicy(params, sig, policy)
+	if !errors.Is(err, ErrReplayedNonce) {
+		t.Errorf("expected ErrReplayedNonce, got %v", err)
+	}
+}
+
+func TestMemoryNonceStore_Seen(t *testing.T) {
+	store := NewMemoryNonceStore(2)
+
+	seen, err := store.Seen("a", time.Minute)
+	if err != nil || seen {
+		t.Fatalf("expected first use of nonce a to be unseen, got seen=%v err=%v", seen, err)
+	}
+
+	seen, err = store.Seen("a", time.Minute)
+	if err != nil || !seen {
+		t.Fatalf("expected replay of nonce a to be seen, got seen=%v err=%v", seen, err)
+	}
+}
+
+func TestMemoryNonceStore_Eviction(t *testing.T) {
+	store := NewMemoryNonceStore(1)
+
+	if _, err := store.Seen("a", time.Minute); err != nil {
+		t.Fatalf("Seen(a): %v", err)
+	}
+	if _, err := store.Seen("b", time.Minute); err != nil {
+		t.Fatalf("Seen(b): %v", err)
+	}
+
+	// "a" should have been evicted to make room for "b", so it is
+	// reported as unseen again.
+	seen, err := store.Seen("a", time.Minute)
+	if err != nil {
+		t.Fatalf("Seen(a) again: %v", err)
+	}
+	if seen {
+		t.Errorf("expected nonce a to have been evicted")
+	}
+}
+
+func TestMemoryNonceStore_Expiry(t *testing.T) {
+	store := NewMemoryNonceStore(10)
+
+	if _, err := store.Seen("a", time.Millisecond); err != nil {
+		t.Fatalf("Seen(a): %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	seen, err := store.Seen("a", time.Minute)
+	if err != nil {
+		t.Fatalf("Seen(a) after expiry: %v", err)
+	}
+	if seen {
+		t.Errorf("expected expired nonce to be treated as unseen")
+	}
+}
+
+type fakeRedisClient struct {
+	keys map[string]bool
+}
+
+func (f *fakeRedisClient) SetNX(key string, ttl time.Duration) (bool, error) {
+	if f.keys[key] {
+		return false, nil
+	}
+	f.keys[key] = true
+	return true, nil
+}
+
+func TestRedisNonceStore_Seen(t *testing.T) {
+	client := &fakeRedisClient{keys: make(map[string]bool)}
+	store := NewRedisNonceStore(client, "seaverse:nonce:")
+
+	seen, err := store.Seen("abc", time.Minute)
+	if err != nil || seen {
+		t.Fatalf("expected first use to be unseen, got seen=%v err=%v", seen, err)
+	}
+
+	seen, err = store.Seen("abc", time.Minute)
+	if err != nil || !seen {
+		t.Fatalf("expected replay to be seen, got seen=%v err=%v", seen, err)
+	}
+}